@@ -0,0 +1,260 @@
+// Package bot runs the Slack ingestion side of the bridge: either Socket
+// Mode or an HTTP Events API server, feeding every message into a bounded
+// worker pool that calls into a bridge/slack.Bridge. It replaces the
+// single RTM goroutine-per-message loop main.go used to run directly.
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os/signal"
+	"runtime"
+	"sync"
+	"syscall"
+	"time"
+
+	slackgo "github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+	"github.com/slack-go/slack/socketmode"
+
+	"github.com/Jacobus52999/go-slackjira/bridge/slack"
+	"github.com/Jacobus52999/go-slackjira/logging"
+)
+
+// Mode selects how the bot receives Slack messages.
+type Mode int
+
+const (
+	// ModeSocket uses Slack's Socket Mode, a long-lived WebSocket
+	// connection that needs no public URL.
+	ModeSocket Mode = iota
+	// ModeEvents runs an HTTP server implementing the Events API,
+	// including the one-time URL verification handshake.
+	ModeEvents
+)
+
+// ParseMode maps a --mode flag value to a Mode. Anything other than
+// "events" is treated as socket mode.
+func ParseMode(s string) Mode {
+	if s == "events" {
+		return ModeEvents
+	}
+	return ModeSocket
+}
+
+const (
+	defaultJobTimeout = 10 * time.Second
+	defaultEventsAddr = ":3002"
+)
+
+// Config configures a Bot.
+type Config struct {
+	Mode       Mode
+	Workers    int           // default runtime.NumCPU()
+	JobTimeout time.Duration // per-message handling deadline, default 10s
+
+	Token         string // xoxb-... bot token
+	AppToken      string // xapp-... app-level token, required for ModeSocket
+	SigningSecret string // required for ModeEvents
+	EventsAddr    string // HTTP listen address for ModeEvents
+}
+
+type job struct {
+	text, channel string
+}
+
+// Bot feeds Slack messages into a bounded worker pool that calls
+// bridge.HandleMessage. Retries against Jira itself are the Importer's
+// job (see bridge/jira's 429/5xx backoff); Bot's concern is not dropping
+// work under load and shutting down cleanly.
+type Bot struct {
+	cfg    Config
+	bridge *slack.Bridge
+	log    *logging.Logger
+
+	jobs chan job
+	wg   sync.WaitGroup
+}
+
+// New builds a Bot that delivers messages to bridge.
+func New(cfg Config, bridge *slack.Bridge, log *logging.Logger) *Bot {
+	if cfg.Workers <= 0 {
+		cfg.Workers = runtime.NumCPU()
+	}
+	if cfg.JobTimeout <= 0 {
+		cfg.JobTimeout = defaultJobTimeout
+	}
+	if cfg.Mode == ModeEvents && cfg.EventsAddr == "" {
+		cfg.EventsAddr = defaultEventsAddr
+	}
+	return &Bot{
+		cfg:    cfg,
+		bridge: bridge,
+		log:    log,
+		jobs:   make(chan job, cfg.Workers*4),
+	}
+}
+
+// Run starts the worker pool and the configured ingestion mode. It blocks
+// until ctx is cancelled or the process receives SIGINT/SIGTERM, then
+// stops accepting new messages and waits for in-flight ones to finish
+// before returning.
+func (b *Bot) Run(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	for i := 0; i < b.cfg.Workers; i++ {
+		b.wg.Add(1)
+		go b.worker(ctx, i)
+	}
+
+	var err error
+	switch b.cfg.Mode {
+	case ModeEvents:
+		err = b.runEvents(ctx)
+	default:
+		err = b.runSocket(ctx)
+	}
+
+	close(b.jobs)
+	b.wg.Wait()
+	return err
+}
+
+func (b *Bot) worker(ctx context.Context, id int) {
+	defer b.wg.Done()
+	for j := range b.jobs {
+		// Detached from ctx's cancellation so jobs still queued or running
+		// at shutdown get their full JobTimeout instead of failing at once.
+		jobCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), b.cfg.JobTimeout)
+		if err := b.bridge.HandleMessage(jobCtx, j.text, j.channel); err != nil {
+			b.log.Error("unfurl failed",
+				logging.F("worker", id),
+				logging.F("channel", j.channel),
+				logging.F("error", err.Error()))
+		}
+		cancel()
+	}
+}
+
+// enqueue hands a message to the worker pool, dropping it only if ctx is
+// already done (shutting down).
+func (b *Bot) enqueue(ctx context.Context, text, channel string) {
+	select {
+	case b.jobs <- job{text: text, channel: channel}:
+	case <-ctx.Done():
+	}
+}
+
+func (b *Bot) runSocket(ctx context.Context) error {
+	api := slackgo.New(b.cfg.Token, slackgo.OptionAppLevelToken(b.cfg.AppToken))
+	client := socketmode.New(api)
+
+	// Tracked on its own WaitGroup, not b.wg: Run closes b.jobs (which the
+	// workers on b.wg range over) right after runSocket returns, so this
+	// goroutine — which calls b.enqueue and so sends on b.jobs — must be
+	// known to have exited before that, not merely before the workers do.
+	// It can't range over client.Events either: socketmode never closes
+	// that channel, even once RunContext returns on ctx cancellation, so
+	// it exits via ctx.Done() instead.
+	var reader sync.WaitGroup
+	reader.Add(1)
+	go func() {
+		defer reader.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case evt, ok := <-client.Events:
+				if !ok {
+					return
+				}
+				if evt.Type != socketmode.EventTypeEventsAPI {
+					continue
+				}
+				event, ok := evt.Data.(slackevents.EventsAPIEvent)
+				if !ok {
+					continue
+				}
+				if evt.Request != nil {
+					client.Ack(*evt.Request)
+				}
+				b.dispatchEventsAPI(ctx, event)
+			}
+		}
+	}()
+
+	b.log.Info("starting socket mode bot", logging.F("workers", b.cfg.Workers))
+	err := client.RunContext(ctx)
+	reader.Wait()
+	if err != nil && ctx.Err() == nil {
+		return err
+	}
+	return nil
+}
+
+func (b *Bot) runEvents(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/slack/events", b.serveEventsAPI)
+
+	server := &http.Server{Addr: b.cfg.EventsAddr, Handler: mux}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), defaultJobTimeout)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	b.log.Info("starting events API bot",
+		logging.F("addr", b.cfg.EventsAddr),
+		logging.F("workers", b.cfg.Workers))
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+func (b *Bot) serveEventsAPI(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "error reading body", http.StatusBadRequest)
+		return
+	}
+	r.Body.Close()
+
+	if err := slack.VerifySigningSecret(b.cfg.SigningSecret, r.Header, body); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	event, err := slackevents.ParseEvent(body, slackevents.OptionNoVerifyToken())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if event.Type == slackevents.URLVerification {
+		var challenge slackevents.ChallengeResponse
+		if err := json.Unmarshal(body, &challenge); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "text")
+		fmt.Fprint(w, challenge.Challenge)
+		return
+	}
+
+	b.dispatchEventsAPI(r.Context(), event)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (b *Bot) dispatchEventsAPI(ctx context.Context, event slackevents.EventsAPIEvent) {
+	inner, ok := event.InnerEvent.Data.(*slackevents.MessageEvent)
+	if !ok || inner.BotID != "" || inner.SubType == "bot_message" {
+		return
+	}
+	b.enqueue(ctx, inner.Text, inner.Channel)
+}
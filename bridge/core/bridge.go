@@ -0,0 +1,87 @@
+// Package core defines the backend-agnostic contract shared by every
+// tracker/chat bridge in go-slackjira. A bridge wires one Importer/Exporter
+// pair (an issue tracker, e.g. bridge/jira) to one or more ChatBridges
+// (e.g. bridge/slack) without either side knowing about the other's
+// concrete implementation.
+package core
+
+import (
+	"context"
+	"net/http"
+)
+
+// Issue is the tracker-agnostic representation of an issue used when
+// rendering unfurls or deciding how to react to chat mentions.
+type Issue struct {
+	Key        string
+	Summary    string
+	Status     string
+	Assignee   string
+	Priority   string
+	URL        string
+	IconURL    string
+	IssueType  string
+	StatusIcon string
+	// Updated is the tracker's last-modified timestamp, used by importers
+	// to decide whether a cached Issue is still fresh.
+	Updated     string
+	Reporter    string
+	Labels      []string
+	Components  []string
+	FixVersions []string
+	Comments    []Comment
+	// Links holds human-readable descriptions of related issues (e.g.
+	// "blocks PROJ-12").
+	Links []string
+	// Fields carries instance-specific attributes an Importer could not
+	// give a first-class name to (e.g. a Jira custom field like Sprint or
+	// Story Points), keyed by the logical name a renderer asked for.
+	Fields map[string]string
+}
+
+// Comment is a single tracker comment, used both when exporting a Slack
+// thread back to the tracker and when rendering recent comments in an
+// unfurl.
+type Comment struct {
+	Author string
+	Body   string
+}
+
+// Authenticator attaches whatever credential a backend needs (basic auth,
+// bearer token, signed request, ...) to an outgoing request. Each backend
+// package provides its own implementations; core only depends on the
+// interface so Importer/Exporter implementations can be built against
+// arbitrary credential sources (env vars, the credentials package, a
+// keyring, ...).
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+// Importer fetches tracker state needed to render it elsewhere (chat
+// unfurls, subscriptions, ...).
+type Importer interface {
+	// FetchIssue looks up a single issue by key.
+	FetchIssue(ctx context.Context, key string) (*Issue, error)
+	// Search runs a tracker-native query (e.g. JQL) and returns matching
+	// issues.
+	Search(ctx context.Context, query string) ([]Issue, error)
+}
+
+// Exporter pushes actions that originated in chat back into the tracker.
+type Exporter interface {
+	AddComment(ctx context.Context, key, body string) error
+	TransitionIssue(ctx context.Context, key, transition string) error
+	AssignIssue(ctx context.Context, key, assignee string) error
+}
+
+// ChatBridge is implemented by each supported chat backend. It is the
+// pluggable half of the system: bridge/slack is the first implementation,
+// with Mattermost/IRC/Discord expected to follow the same shape. A
+// ChatBridge only renders; it has no opinion on how messages reach it —
+// that's up to whatever runs it (e.g. the bot package for bridge/slack).
+type ChatBridge interface {
+	// Name identifies the bridge in logs and config, e.g. "slack".
+	Name() string
+	// Unfurl renders a tracker issue into the given channel.
+	Unfurl(ctx context.Context, issue Issue, channel string) error
+}
@@ -0,0 +1,47 @@
+package jira
+
+import (
+	"context"
+	"time"
+
+	"github.com/Jacobus52999/go-slackjira/bridge/core"
+	"github.com/Jacobus52999/go-slackjira/cache"
+)
+
+// CachingClient decorates a Client with an in-memory LRU cache so repeated
+// mentions of the same issue within ttl don't re-hit Jira. Entries are
+// keyed by issue key; a cached Issue is returned as-is until it expires,
+// at which point the next FetchIssue call re-fetches and re-caches it
+// (along with its current Updated timestamp).
+type CachingClient struct {
+	*Client
+	cache  *cache.LRU
+	fields []string
+}
+
+// NewCachingClient wraps client with an LRU of the given size and TTL.
+// fields restricts what FetchIssue requests from Jira (via `?fields=`);
+// pass nil to fetch Jira's default field set.
+func NewCachingClient(client *Client, size int, ttl time.Duration, fields []string) *CachingClient {
+	return &CachingClient{
+		Client: client,
+		cache:  cache.New(size, ttl),
+		fields: fields,
+	}
+}
+
+// FetchIssue implements core.Importer, serving cached issues when
+// available.
+func (c *CachingClient) FetchIssue(ctx context.Context, key string) (*core.Issue, error) {
+	if cached, ok := c.cache.Get(key); ok {
+		issue := cached.(*core.Issue)
+		return issue, nil
+	}
+
+	issue, err := c.Client.FetchIssueFields(ctx, key, c.fields)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.Set(key, issue)
+	return issue, nil
+}
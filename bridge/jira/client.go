@@ -0,0 +1,434 @@
+// Package jira implements core.Importer and core.Exporter against the
+// Jira v3 REST API, with Basic or OAuth-style token auth, pagination and
+// rate-limit backoff.
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Jacobus52999/go-slackjira/bridge/core"
+)
+
+const (
+	issueAPI       = "/rest/api/3/issue/"
+	projectAPI     = "/rest/api/3/project"
+	searchAPI      = "/rest/api/3/search"
+	transitionsAPI = "/rest/api/3/issue/%s/transitions"
+	commentAPI     = "/rest/api/3/issue/%s/comment"
+	assigneeAPI    = "/rest/api/3/issue/%s/assignee"
+
+	searchPageSize = 50
+	maxRetries     = 4
+)
+
+// Client is a Jira v3 REST client. It implements core.Importer and
+// core.Exporter, so a single Client can serve as both halves of the
+// tracker side of a bridge.
+type Client struct {
+	baseURL      *url.URL
+	cred         core.Authenticator
+	httpClient   *http.Client
+	customFields map[string]string
+}
+
+// NewClient builds a Client for the given base URL, authenticating with
+// cred on every request.
+func NewClient(baseURL *url.URL, cred core.Authenticator) *Client {
+	return &Client{
+		baseURL:    baseURL,
+		cred:       cred,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// WithCustomFields records the Jira custom field IDs (e.g.
+// "customfield_10010") behind instance-specific logical names such as
+// "sprint" or "storyPoints". FetchIssue populates core.Issue.Fields with
+// one entry per configured name when the underlying issue has it set.
+func (c *Client) WithCustomFields(fields map[string]string) *Client {
+	clone := *c
+	clone.customFields = fields
+	return &clone
+}
+
+// do sends req, retrying on 429/5xx with exponential backoff honoring a
+// Retry-After header when Jira sends one.
+func (c *Client) do(ctx context.Context, req *http.Request) (int, []byte, error) {
+	if err := c.cred.Authenticate(req); err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req = req.WithContext(ctx)
+
+	var body []byte
+	if req.GetBody != nil {
+		b, err := req.GetBody()
+		if err != nil {
+			return 0, nil, err
+		}
+		body, _ = io.ReadAll(b)
+	}
+
+	backoff := 500 * time.Millisecond
+	for attempt := 0; ; attempt++ {
+		if body != nil {
+			req.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return 0, nil, err
+		}
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return resp.StatusCode, nil, err
+		}
+
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		if !retryable || attempt >= maxRetries {
+			return resp.StatusCode, data, nil
+		}
+
+		wait := backoff
+		if ra := resp.Header.Get("Retry-After"); ra != "" {
+			if secs, err := strconv.Atoi(ra); err == nil {
+				wait = time.Duration(secs) * time.Second
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return resp.StatusCode, data, ctx.Err()
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+}
+
+func (c *Client) get(ctx context.Context, path string, out interface{}) error {
+	data, err := c.getRaw(ctx, path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+func (c *Client) getRaw(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, c.baseURL.String()+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	code, data, err := c.do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if code != http.StatusOK {
+		return nil, fmt.Errorf("jira: GET %s: status %d", path, code)
+	}
+	return data, nil
+}
+
+func (c *Client) send(ctx context.Context, method, path string, in, out interface{}) (int, error) {
+	var bodyReader io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return 0, err
+		}
+		bodyReader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequest(method, c.baseURL.String()+path, bodyReader)
+	if err != nil {
+		return 0, err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+		req.GetBody = func() (io.ReadCloser, error) {
+			payload, _ := json.Marshal(in)
+			return io.NopCloser(bytes.NewReader(payload)), nil
+		}
+	}
+	code, data, err := c.do(ctx, req)
+	if err != nil {
+		return code, err
+	}
+	if out != nil && len(data) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			return code, err
+		}
+	}
+	return code, nil
+}
+
+// GetProjects returns every project visible to the configured credential.
+func (c *Client) GetProjects(ctx context.Context) ([]Project, error) {
+	var projects []Project
+	if err := c.get(ctx, projectAPI, &projects); err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// FetchIssue implements core.Importer, fetching every field Jira returns
+// by default. Use FetchIssueFields to request a specific subset.
+func (c *Client) FetchIssue(ctx context.Context, key string) (*core.Issue, error) {
+	return c.FetchIssueFields(ctx, key, nil)
+}
+
+// FetchIssueFields fetches key, restricting the response to fields (via
+// Jira's `?fields=` query parameter) when fields is non-empty. A nil or
+// empty fields list fetches Jira's default field set. Any logical names
+// registered with WithCustomFields are added to a non-empty fields list
+// (the default set already includes them) and populate the returned
+// Issue's Fields map.
+func (c *Client) FetchIssueFields(ctx context.Context, key string, fields []string) (*core.Issue, error) {
+	path := issueAPI + key
+	requested := append([]string{}, fields...)
+	if len(fields) > 0 {
+		for _, id := range c.customFields {
+			requested = append(requested, id)
+		}
+	}
+	if len(requested) > 0 {
+		q := url.Values{}
+		q.Set("fields", strings.Join(requested, ","))
+		path += "?" + q.Encode()
+	}
+
+	data, err := c.getRaw(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw issue
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if raw.Key == "" {
+		return nil, fmt.Errorf("jira: no issue found for %q", key)
+	}
+	out := c.toCoreIssue(raw)
+
+	if len(c.customFields) > 0 {
+		var rawFields struct {
+			Fields map[string]json.RawMessage `json:"fields"`
+		}
+		if err := json.Unmarshal(data, &rawFields); err == nil {
+			out.Fields = extractCustomFields(rawFields.Fields, c.customFields)
+		}
+	}
+	return out, nil
+}
+
+// Search implements core.Importer, paginating through /rest/api/3/search
+// until all matching issues have been collected.
+func (c *Client) Search(ctx context.Context, jql string) ([]core.Issue, error) {
+	var out []core.Issue
+	startAt := 0
+	for {
+		q := url.Values{}
+		q.Set("jql", jql)
+		q.Set("startAt", strconv.Itoa(startAt))
+		q.Set("maxResults", strconv.Itoa(searchPageSize))
+
+		var page searchResult
+		if err := c.get(ctx, searchAPI+"?"+q.Encode(), &page); err != nil {
+			return nil, err
+		}
+		for _, raw := range page.Issues {
+			out = append(out, *c.toCoreIssue(raw))
+		}
+		startAt += len(page.Issues)
+		if startAt >= page.Total || len(page.Issues) == 0 {
+			break
+		}
+	}
+	return out, nil
+}
+
+// AddComment implements core.Exporter.
+func (c *Client) AddComment(ctx context.Context, key, body string) error {
+	payload := map[string]string{"body": body}
+	code, err := c.send(ctx, http.MethodPost, fmt.Sprintf(commentAPI, key), payload, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusCreated && code != http.StatusOK {
+		return fmt.Errorf("jira: add comment on %s: status %d", key, code)
+	}
+	return nil
+}
+
+// Transitions lists the transitions currently available for key, e.g. to
+// resolve a transition name to the ID TransitionIssue requires.
+func (c *Client) Transitions(ctx context.Context, key string) ([]transition, error) {
+	var result transitionsResult
+	if err := c.get(ctx, fmt.Sprintf(transitionsAPI, key), &result); err != nil {
+		return nil, err
+	}
+	return result.Transitions, nil
+}
+
+// TransitionIssue implements core.Exporter, resolving the human-readable
+// transition name (e.g. "In Progress") to its ID before applying it.
+func (c *Client) TransitionIssue(ctx context.Context, key, transitionName string) error {
+	available, err := c.Transitions(ctx, key)
+	if err != nil {
+		return err
+	}
+	var id string
+	for _, t := range available {
+		if t.Name == transitionName {
+			id = t.ID
+			break
+		}
+	}
+	if id == "" {
+		return fmt.Errorf("jira: %s has no transition named %q", key, transitionName)
+	}
+	payload := map[string]interface{}{
+		"transition": map[string]string{"id": id},
+	}
+	code, err := c.send(ctx, http.MethodPost, fmt.Sprintf(transitionsAPI, key), payload, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusNoContent {
+		return fmt.Errorf("jira: transition %s to %q: status %d", key, transitionName, code)
+	}
+	return nil
+}
+
+// AssignIssue implements core.Exporter.
+func (c *Client) AssignIssue(ctx context.Context, key, assignee string) error {
+	payload := map[string]string{"accountId": assignee}
+	code, err := c.send(ctx, http.MethodPut, fmt.Sprintf(assigneeAPI, key), payload, nil)
+	if err != nil {
+		return err
+	}
+	if code != http.StatusNoContent {
+		return fmt.Errorf("jira: assign %s to %q: status %d", key, assignee, code)
+	}
+	return nil
+}
+
+func (c *Client) toCoreIssue(raw issue) *core.Issue {
+	out := &core.Issue{Key: raw.Key, URL: fmt.Sprintf("%s/browse/%s", strings.TrimSuffix(c.baseURL.String(), "/"), raw.Key)}
+	f := raw.Fields
+	if f == nil {
+		return out
+	}
+	out.Summary = f.Summary
+	out.Updated = f.Updated
+	out.Labels = f.Labels
+	if f.IssueType != nil {
+		out.IssueType = f.IssueType.Name
+	}
+	if f.Reporter != nil {
+		out.Reporter = f.Reporter.DisplayName
+	}
+	if f.Assignee != nil {
+		out.Assignee = f.Assignee.DisplayName
+	} else {
+		out.Assignee = "Unassigned"
+	}
+	if f.Priority != nil {
+		out.Priority = f.Priority.Name
+	}
+	if f.Status != nil {
+		out.Status = f.Status.Name
+		out.StatusIcon = f.Status.IconURL
+	}
+	for _, c := range f.Components {
+		out.Components = append(out.Components, c.Name)
+	}
+	for _, v := range f.FixVersions {
+		out.FixVersions = append(out.FixVersions, v.Name)
+	}
+	if f.Comment != nil {
+		for _, rc := range f.Comment.Comments {
+			comment := core.Comment{Body: adfPlainText(rc.Body)}
+			if rc.Author != nil {
+				comment.Author = rc.Author.DisplayName
+			}
+			out.Comments = append(out.Comments, comment)
+		}
+	}
+	for _, link := range f.IssueLinks {
+		switch {
+		case link.OutwardIssue != nil:
+			out.Links = append(out.Links, fmt.Sprintf("%s %s", link.Type.Outward, link.OutwardIssue.Key))
+		case link.InwardIssue != nil:
+			out.Links = append(out.Links, fmt.Sprintf("%s %s", link.Type.Inward, link.InwardIssue.Key))
+		}
+	}
+	return out
+}
+
+// extractCustomFields stringifies the raw Jira fields named in ids (a
+// logical name -> field ID map) so they can be rendered by a text
+// template without the renderer needing to know Jira's JSON shape.
+func extractCustomFields(raw map[string]json.RawMessage, ids map[string]string) map[string]string {
+	out := make(map[string]string, len(ids))
+	for name, id := range ids {
+		value, ok := raw[id]
+		if !ok || string(value) == "null" {
+			continue
+		}
+		out[name] = stringifyField(value)
+	}
+	return out
+}
+
+func stringifyField(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var n json.Number
+	if json.Unmarshal(raw, &n) == nil {
+		return n.String()
+	}
+	var named namedRef
+	if json.Unmarshal(raw, &named) == nil && named.Name != "" {
+		return named.Name
+	}
+	return string(raw)
+}
+
+// adfPlainText best-effort extracts plain text out of a Jira v3 comment
+// body, which may be a plain string (API v2 compatibility mode) or an
+// Atlassian Document Format node tree.
+func adfPlainText(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var doc struct {
+		Content []struct {
+			Content []struct {
+				Text string `json:"text"`
+			} `json:"content"`
+		} `json:"content"`
+	}
+	if json.Unmarshal(raw, &doc) != nil {
+		return ""
+	}
+	var parts []string
+	for _, block := range doc.Content {
+		for _, node := range block.Content {
+			if node.Text != "" {
+				parts = append(parts, node.Text)
+			}
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,96 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Jacobus52999/go-slackjira/bridge/core"
+)
+
+// Router dispatches to the Client configured for an issue key's project,
+// so a single process (e.g. cmd/interactions or cmd/webhook) can serve
+// several Jira instances through one core.Importer/core.Exporter instead
+// of being pinned to whichever Client it happens to hold.
+type Router struct {
+	clients []*Client
+	keys    [][]string
+}
+
+// NewRouter builds an empty Router; add instances to it with Add.
+func NewRouter() *Router {
+	return &Router{}
+}
+
+// Add registers client as the instance serving the given project keys.
+func (r *Router) Add(client *Client, projectKeys []string) {
+	r.clients = append(r.clients, client)
+	r.keys = append(r.keys, projectKeys)
+}
+
+// ClientFor returns the Client configured for issueKey's project (the part
+// before the last "-", e.g. "MYPROJ" in "MYPROJ-123").
+func (r *Router) ClientFor(issueKey string) (*Client, error) {
+	project := issueKey
+	if i := strings.LastIndex(issueKey, "-"); i > 0 {
+		project = issueKey[:i]
+	}
+	for i, keys := range r.keys {
+		for _, k := range keys {
+			if k == project {
+				return r.clients[i], nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("jira: no configured instance serves project %q", project)
+}
+
+// FetchIssue implements core.Importer, routing by key's project.
+func (r *Router) FetchIssue(ctx context.Context, key string) (*core.Issue, error) {
+	client, err := r.ClientFor(key)
+	if err != nil {
+		return nil, err
+	}
+	return client.FetchIssue(ctx, key)
+}
+
+// Search implements core.Importer. A JQL query isn't tied to one project,
+// so Search fans the query out to every configured instance.
+func (r *Router) Search(ctx context.Context, jql string) ([]core.Issue, error) {
+	var out []core.Issue
+	for _, client := range r.clients {
+		issues, err := client.Search(ctx, jql)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, issues...)
+	}
+	return out, nil
+}
+
+// AddComment implements core.Exporter, routing by key's project.
+func (r *Router) AddComment(ctx context.Context, key, body string) error {
+	client, err := r.ClientFor(key)
+	if err != nil {
+		return err
+	}
+	return client.AddComment(ctx, key, body)
+}
+
+// TransitionIssue implements core.Exporter, routing by key's project.
+func (r *Router) TransitionIssue(ctx context.Context, key, transition string) error {
+	client, err := r.ClientFor(key)
+	if err != nil {
+		return err
+	}
+	return client.TransitionIssue(ctx, key, transition)
+}
+
+// AssignIssue implements core.Exporter, routing by key's project.
+func (r *Router) AssignIssue(ctx context.Context, key, assignee string) error {
+	client, err := r.ClientFor(key)
+	if err != nil {
+		return err
+	}
+	return client.AssignIssue(ctx, key, assignee)
+}
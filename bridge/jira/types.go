@@ -0,0 +1,104 @@
+package jira
+
+import "encoding/json"
+
+// The wire types below mirror the subset of the Jira v3 REST schema this
+// bridge cares about. They stay unexported-field-free (all exported) so
+// encoding/json can populate them directly, and are converted to
+// core.Issue at the edge via toCoreIssue.
+
+// Project is a Jira project.
+type Project struct {
+	ID  string `json:"id"`
+	Key string `json:"key"`
+}
+
+// issue is the raw /rest/api/3/issue/{key} response shape.
+type issue struct {
+	Key    string       `json:"key"`
+	Fields *issueFields `json:"fields"`
+}
+
+type issueFields struct {
+	IssueType   *issueType    `json:"issuetype"`
+	Summary     string        `json:"summary"`
+	Creator     *user         `json:"creator"`
+	Reporter    *user         `json:"reporter"`
+	Assignee    *user         `json:"assignee"`
+	Priority    *priority     `json:"priority"`
+	Status      *status       `json:"status"`
+	Updated     string        `json:"updated"`
+	Labels      []string      `json:"labels"`
+	Components  []namedRef    `json:"components"`
+	FixVersions []namedRef    `json:"fixVersions"`
+	Comment     *commentField `json:"comment"`
+	IssueLinks  []issueLink   `json:"issuelinks"`
+}
+
+// namedRef covers the { "name": "..." } shape shared by components, fix
+// versions and a few other Jira reference fields.
+type namedRef struct {
+	Name string `json:"name"`
+}
+
+type commentField struct {
+	Comments []rawComment `json:"comments"`
+}
+
+type rawComment struct {
+	Author *user           `json:"author"`
+	Body   json.RawMessage `json:"body"`
+}
+
+// issueLink is one entry of the issuelinks field; exactly one of
+// OutwardIssue/InwardIssue is populated depending on the link's direction.
+type issueLink struct {
+	Type struct {
+		Outward string `json:"outward"`
+		Inward  string `json:"inward"`
+	} `json:"type"`
+	OutwardIssue *issueRef `json:"outwardIssue"`
+	InwardIssue  *issueRef `json:"inwardIssue"`
+}
+
+type issueRef struct {
+	Key string `json:"key"`
+}
+
+type issueType struct {
+	IconURL string `json:"iconUrl"`
+	Name    string `json:"name"`
+}
+
+type user struct {
+	DisplayName string `json:"displayName"`
+}
+
+type priority struct {
+	Name    string `json:"name"`
+	IconURL string `json:"iconUrl"`
+}
+
+type status struct {
+	Name    string `json:"name"`
+	IconURL string `json:"iconUrl"`
+}
+
+// searchResult is the raw /rest/api/3/search response shape, paginated via
+// startAt/maxResults/total.
+type searchResult struct {
+	StartAt    int     `json:"startAt"`
+	MaxResults int     `json:"maxResults"`
+	Total      int     `json:"total"`
+	Issues     []issue `json:"issues"`
+}
+
+// transition is one entry of /rest/api/3/issue/{key}/transitions.
+type transition struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type transitionsResult struct {
+	Transitions []transition `json:"transitions"`
+}
@@ -0,0 +1,111 @@
+package jira
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// WebhookEvent is the subset of a Jira webhook payload (jira:issue_created,
+// jira:issue_updated, comment_created, sprint_started, ...) this bridge
+// acts on.
+type WebhookEvent struct {
+	Type      string            `json:"webhookEvent"`
+	Issue     *issue            `json:"issue"`
+	Comment   *rawComment       `json:"comment"`
+	Changelog *webhookChangelog `json:"changelog"`
+	Sprint    *webhookSprint    `json:"sprint"`
+}
+
+// webhookSprint is the payload Jira sends for sprint_started/sprint_closed
+// events, which have no issue object to key off of.
+type webhookSprint struct {
+	Name          string `json:"name"`
+	OriginBoardID int    `json:"originBoardId"`
+}
+
+type webhookChangelog struct {
+	Items []webhookChangeItem `json:"items"`
+}
+
+type webhookChangeItem struct {
+	Field      string `json:"field"`
+	FromString string `json:"fromString"`
+	ToString   string `json:"toString"`
+}
+
+// ParseWebhookEvent decodes a Jira webhook POST body.
+func ParseWebhookEvent(body []byte) (*WebhookEvent, error) {
+	var event WebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("jira: parsing webhook payload: %w", err)
+	}
+	return &event, nil
+}
+
+// IssueKey returns the key of the issue the event is about, or "" if the
+// payload didn't include one (e.g. a project-level event).
+func (e *WebhookEvent) IssueKey() string {
+	if e.Issue == nil {
+		return ""
+	}
+	return e.Issue.Key
+}
+
+// BoardContext describes the board a board-level event (e.g.
+// sprint_started) belongs to, for events that carry no issue to key a JQL
+// search off of. It returns "" for issue-level events.
+func (e *WebhookEvent) BoardContext() string {
+	if e.Sprint == nil {
+		return ""
+	}
+	return fmt.Sprintf("board %d", e.Sprint.OriginBoardID)
+}
+
+// ChangeSummary renders the event's changelog, if any, as human-readable
+// "field: Before -> After" lines, using the field name as Jira's changelog
+// reports it (e.g. "status: Open -> In Progress").
+func (e *WebhookEvent) ChangeSummary() []string {
+	if e.Changelog == nil {
+		return nil
+	}
+	lines := make([]string, 0, len(e.Changelog.Items))
+	for _, item := range e.Changelog.Items {
+		lines = append(lines, fmt.Sprintf("%s: %s -> %s", item.Field, item.FromString, item.ToString))
+	}
+	return lines
+}
+
+// StatusChange reports the status transition carried by the event's
+// changelog, if any, so callers can color-code the notification.
+func (e *WebhookEvent) StatusChange() (from, to string, changed bool) {
+	if e.Changelog == nil {
+		return "", "", false
+	}
+	for _, item := range e.Changelog.Items {
+		if item.Field == "status" {
+			return item.FromString, item.ToString, true
+		}
+	}
+	return "", "", false
+}
+
+// VerifyWebhookSignature checks the shared-secret HMAC-SHA256 signature a
+// webhook subscriber is expected to send as "sha256=<hex digest of the
+// raw body>" in the given header value.
+func VerifyWebhookSignature(secret, signatureHeader string, body []byte) error {
+	if signatureHeader == "" {
+		return fmt.Errorf("jira: missing webhook signature")
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(signatureHeader)) != 1 {
+		return fmt.Errorf("jira: webhook signature mismatch")
+	}
+	return nil
+}
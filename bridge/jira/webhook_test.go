@@ -0,0 +1,97 @@
+package jira
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func signWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyWebhookSignatureValid(t *testing.T) {
+	body := []byte(`{"webhookEvent":"jira:issue_updated"}`)
+	if err := VerifyWebhookSignature("shhh", signWebhook("shhh", body), body); err != nil {
+		t.Fatalf("VerifyWebhookSignature() = %v, want nil", err)
+	}
+}
+
+func TestVerifyWebhookSignatureMismatch(t *testing.T) {
+	body := []byte(`{"webhookEvent":"jira:issue_updated"}`)
+	if err := VerifyWebhookSignature("shhh", signWebhook("wrong-secret", body), body); err == nil {
+		t.Fatal("VerifyWebhookSignature() = nil, want error for mismatched signature")
+	}
+}
+
+func TestVerifyWebhookSignatureMissing(t *testing.T) {
+	if err := VerifyWebhookSignature("shhh", "", []byte("body")); err == nil {
+		t.Fatal("VerifyWebhookSignature() = nil, want error for missing signature header")
+	}
+}
+
+func TestWebhookEventChangeSummary(t *testing.T) {
+	event, err := ParseWebhookEvent([]byte(`{
+		"webhookEvent": "jira:issue_updated",
+		"issue": {"key": "PROJ-1"},
+		"changelog": {"items": [
+			{"field": "status", "fromString": "Open", "toString": "In Progress"},
+			{"field": "assignee", "fromString": "", "toString": "alice"}
+		]}
+	}`))
+	if err != nil {
+		t.Fatalf("ParseWebhookEvent() error = %v", err)
+	}
+
+	if got := event.IssueKey(); got != "PROJ-1" {
+		t.Fatalf("IssueKey() = %q, want %q", got, "PROJ-1")
+	}
+
+	want := []string{"status: Open -> In Progress", "assignee:  -> alice"}
+	got := event.ChangeSummary()
+	if len(got) != len(want) {
+		t.Fatalf("ChangeSummary() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ChangeSummary()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	from, to, changed := event.StatusChange()
+	if !changed || from != "Open" || to != "In Progress" {
+		t.Fatalf("StatusChange() = %q, %q, %v; want Open, In Progress, true", from, to, changed)
+	}
+}
+
+func TestWebhookEventNoChangelog(t *testing.T) {
+	event, err := ParseWebhookEvent([]byte(`{"webhookEvent": "comment_created", "issue": {"key": "PROJ-2"}}`))
+	if err != nil {
+		t.Fatalf("ParseWebhookEvent() error = %v", err)
+	}
+	if got := event.ChangeSummary(); got != nil {
+		t.Fatalf("ChangeSummary() = %v, want nil", got)
+	}
+	if _, _, changed := event.StatusChange(); changed {
+		t.Fatal("StatusChange() changed = true, want false")
+	}
+}
+
+func TestWebhookEventBoardContext(t *testing.T) {
+	event, err := ParseWebhookEvent([]byte(`{
+		"webhookEvent": "sprint_started",
+		"sprint": {"name": "Sprint 7", "originBoardId": 42}
+	}`))
+	if err != nil {
+		t.Fatalf("ParseWebhookEvent() error = %v", err)
+	}
+	if got := event.IssueKey(); got != "" {
+		t.Fatalf("IssueKey() = %q, want \"\"", got)
+	}
+	if got, want := event.BoardContext(), "board 42"; got != want {
+		t.Fatalf("BoardContext() = %q, want %q", got, want)
+	}
+}
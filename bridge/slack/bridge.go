@@ -0,0 +1,217 @@
+// Package slack implements core.ChatBridge: spotting issue mentions and
+// rendering unfurls/notifications against the Slack Web API. It is the
+// first of what should become several interchangeable chat backends
+// (Mattermost, IRC, Discord, ...); nothing in it is Jira-aware, it only
+// talks to a core.Importer. Message ingestion (RTM, Socket Mode, the
+// Events API) is handled by the bot package, which calls HandleMessage.
+package slack
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/slack-go/slack"
+
+	"github.com/Jacobus52999/go-slackjira/bridge/core"
+)
+
+const (
+	iconURL = "https://globus.atlassian.net/images/64jira.png"
+	yellow  = "#FFD442"
+	green   = "#048A25"
+	blue    = "#496686"
+)
+
+// Route maps one Jira instance's project keys to the Importer that serves
+// them, so a single Bridge can unfurl issues from several Jira instances
+// (e.g. a Cloud instance and an on-prem Server) in the same workspace.
+type Route struct {
+	// Instance names the route for logging; it has no effect on matching.
+	Instance string
+	// Importer fetches the issues mentioned in chat for this instance.
+	Importer core.Importer
+	// ProjectKeys seeds the regex used to spot issue mentions (e.g.
+	// "MYPROJ" matches "MYPROJ-123") that belong to this instance.
+	ProjectKeys []string
+}
+
+// Options configures a Bridge. Routes is required; the rest have workable
+// zero values.
+type Options struct {
+	// Routes lists the Jira instances this bridge unfurls issues from.
+	Routes []Route
+	// Template controls which fields an unfurl renders. A nil Template
+	// reproduces the bridge's original Summary/Assignee/Priority
+	// attachment.
+	Template *TemplateConfig
+	// DedupWindow suppresses re-unfurling the same issue in the same
+	// channel more than once within this window. Zero disables
+	// de-duplication.
+	DedupWindow time.Duration
+}
+
+// Bridge is the Slack half of a tracker/chat bridge: it knows how to spot
+// issue mentions and render unfurls, but not how messages reach it. That
+// belongs to whatever ingestion the caller chose (see the top-level bot
+// package for the Socket Mode/Events API worker-pool runner).
+type Bridge struct {
+	client    *slack.Client
+	importers []core.Importer
+	pattern   *regexp.Regexp
+	template  *TemplateConfig
+
+	dedupWindow time.Duration
+	dedupMu     sync.Mutex
+	recent      map[string]map[string]time.Time // channel -> issue key -> last unfurled
+}
+
+// New builds a Slack ChatBridge from opts.
+func New(token string, opts Options) *Bridge {
+	tmpl := opts.Template
+	if tmpl == nil {
+		tmpl = defaultTemplateConfig()
+	}
+	importers := make([]core.Importer, len(opts.Routes))
+	for i, route := range opts.Routes {
+		importers[i] = route.Importer
+	}
+	return &Bridge{
+		client:      slack.New(token),
+		importers:   importers,
+		pattern:     buildPattern(opts.Routes),
+		template:    tmpl,
+		dedupWindow: opts.DedupWindow,
+		recent:      make(map[string]map[string]time.Time),
+	}
+}
+
+// Name identifies the bridge in logs, e.g. for the bot package's
+// structured log fields.
+func (b *Bridge) Name() string { return "slack" }
+
+// HandleMessage looks for issue mentions in text and unfurls each of
+// them (once per channel per DedupWindow) into channel. It does its own
+// work synchronously; callers that want concurrency run it from their
+// own worker pool (see the bot package).
+func (b *Bridge) HandleMessage(ctx context.Context, text, channel string) error {
+	names := b.pattern.SubexpNames()
+	seen := make(map[string]bool)
+	for _, match := range b.pattern.FindAllStringSubmatch(text, -1) {
+		key, route, ok := matchedKey(names, match)
+		if !ok || key == "" || seen[key] || !b.shouldUnfurl(channel, key) {
+			continue
+		}
+		seen[key] = true
+
+		issue, err := b.importers[route].FetchIssue(ctx, key)
+		if err != nil {
+			return err
+		}
+		if err := b.Unfurl(ctx, *issue, channel); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchedKey finds the named capture group ("r0", "r1", ...) that matched
+// in match, per the per-route group naming buildPattern uses, and returns
+// its value along with the route index encoded in the group's name.
+func matchedKey(names, match []string) (key string, route int, ok bool) {
+	for i, name := range names {
+		if !strings.HasPrefix(name, "r") || match[i] == "" {
+			continue
+		}
+		route, err := strconv.Atoi(name[1:])
+		if err != nil {
+			continue
+		}
+		return match[i], route, true
+	}
+	return "", 0, false
+}
+
+// shouldUnfurl reports whether key should be unfurled into channel now,
+// given DedupWindow, and records that it was (so the same key mentioned
+// twice in a message, or again within the window, is skipped).
+func (b *Bridge) shouldUnfurl(channel, key string) bool {
+	if b.dedupWindow <= 0 {
+		return true
+	}
+	b.dedupMu.Lock()
+	defer b.dedupMu.Unlock()
+
+	now := time.Now()
+	perChannel, ok := b.recent[channel]
+	if !ok {
+		perChannel = make(map[string]time.Time)
+		b.recent[channel] = perChannel
+	}
+	if last, ok := perChannel[key]; ok && now.Sub(last) < b.dedupWindow {
+		return false
+	}
+	perChannel[key] = now
+	return true
+}
+
+// Unfurl implements core.ChatBridge.
+func (b *Bridge) Unfurl(ctx context.Context, issue core.Issue, channel string) error {
+	fields, err := b.template.render(issue)
+	if err != nil {
+		return err
+	}
+	attachment := slack.Attachment{
+		Title:      issue.Key,
+		TitleLink:  issue.URL,
+		Text:       issue.Summary,
+		Color:      getColor(issue.Status),
+		Fields:     fields,
+		MarkdownIn: []string{"text", "pretext"},
+		CallbackID: issueCallbackID(issue.Key),
+		Actions:    issueActions(),
+	}
+	_, _, err = b.client.PostMessage(channel,
+		slack.MsgOptionUsername("Jira"),
+		slack.MsgOptionIconURL(iconURL),
+		slack.MsgOptionAttachments(attachment))
+	return err
+}
+
+// buildPattern combines every route's project keys into a single regex,
+// one named capture group "r<i>" per route (i being its index into
+// routes), so a match can be traced back to the Importer that should
+// serve it. See matchedKey.
+func buildPattern(routes []Route) *regexp.Regexp {
+	var alternatives []string
+	for i, route := range routes {
+		if len(route.ProjectKeys) == 0 {
+			continue
+		}
+		alternatives = append(alternatives, fmt.Sprintf(`(?:\W|^)(?P<r%d>(?:%s)-\d+)(?:\+)?`, i, strings.Join(route.ProjectKeys, "|")))
+	}
+	alternatives = append(alternatives, `$`)
+	return regexp.MustCompile(strings.Join(alternatives, "|"))
+}
+
+// ColorForStatus exposes the unfurl/notification color for a Jira status
+// name so other packages (e.g. cmd/webhook) stay visually consistent with
+// unfurls without duplicating the status->color mapping.
+func ColorForStatus(status string) string {
+	return getColor(status)
+}
+
+func getColor(status string) string {
+	switch status {
+	case "Open", "Reopened", "To Do":
+		return blue
+	case "Resolved", "Closed", "Done":
+		return green
+	default:
+		return yellow
+	}
+}
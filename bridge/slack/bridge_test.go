@@ -0,0 +1,106 @@
+package slack
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Jacobus52999/go-slackjira/bridge/core"
+)
+
+type stubImporter struct {
+	issues map[string]*core.Issue
+}
+
+func (s *stubImporter) FetchIssue(ctx context.Context, key string) (*core.Issue, error) {
+	return s.issues[key], nil
+}
+
+func (s *stubImporter) Search(ctx context.Context, query string) ([]core.Issue, error) {
+	return nil, nil
+}
+
+func TestBuildPatternRoutesByProject(t *testing.T) {
+	routes := []Route{
+		{Instance: "cloud", ProjectKeys: []string{"CLOUD", "OPS"}},
+		{Instance: "onprem", ProjectKeys: []string{"LEGACY"}},
+	}
+	pattern := buildPattern(routes)
+	names := pattern.SubexpNames()
+
+	tests := []struct {
+		text      string
+		wantKey   string
+		wantRoute int
+	}{
+		{"please look at CLOUD-123", "CLOUD-123", 0},
+		{"OPS-7 is on fire", "OPS-7", 0},
+		{"ping LEGACY-42 for status", "LEGACY-42", 1},
+	}
+
+	for _, tt := range tests {
+		match := pattern.FindStringSubmatch(tt.text)
+		if match == nil {
+			t.Errorf("FindStringSubmatch(%q) = nil, want a match", tt.text)
+			continue
+		}
+		key, route, ok := matchedKey(names, match)
+		if !ok {
+			t.Errorf("matchedKey(%q) ok = false, want true", tt.text)
+			continue
+		}
+		if key != tt.wantKey || route != tt.wantRoute {
+			t.Errorf("matchedKey(%q) = %q, %d; want %q, %d", tt.text, key, route, tt.wantKey, tt.wantRoute)
+		}
+	}
+}
+
+func TestBuildPatternNoMatch(t *testing.T) {
+	routes := []Route{{Instance: "cloud", ProjectKeys: []string{"CLOUD"}}}
+	pattern := buildPattern(routes)
+	names := pattern.SubexpNames()
+
+	match := pattern.FindStringSubmatch("no issue keys in this message")
+	if match != nil {
+		if _, _, ok := matchedKey(names, match); ok {
+			t.Errorf("matchedKey matched %q against a message with no issue key", match[0])
+		}
+	}
+}
+
+func TestMatchedRouteDispatchesToCorrectImporter(t *testing.T) {
+	cloud := &stubImporter{issues: map[string]*core.Issue{
+		"CLOUD-1": {Key: "CLOUD-1", Summary: "from cloud"},
+	}}
+	onprem := &stubImporter{issues: map[string]*core.Issue{
+		"LEGACY-1": {Key: "LEGACY-1", Summary: "from onprem"},
+	}}
+
+	b := New("", Options{
+		Routes: []Route{
+			{Instance: "cloud", Importer: cloud, ProjectKeys: []string{"CLOUD"}},
+			{Instance: "onprem", Importer: onprem, ProjectKeys: []string{"LEGACY"}},
+		},
+	})
+
+	names := b.pattern.SubexpNames()
+	var fetched []string
+	for _, text := range []string{"see CLOUD-1", "see LEGACY-1"} {
+		match := b.pattern.FindStringSubmatch(text)
+		key, route, ok := matchedKey(names, match)
+		if !ok {
+			t.Fatalf("matchedKey(%q) ok = false", text)
+		}
+		issue, err := b.importers[route].FetchIssue(context.Background(), key)
+		if err != nil {
+			t.Fatalf("FetchIssue(%q) error = %v", key, err)
+		}
+		fetched = append(fetched, issue.Summary)
+	}
+
+	want := []string{"from cloud", "from onprem"}
+	for i := range want {
+		if fetched[i] != want[i] {
+			t.Errorf("fetched[%d] = %q, want %q", i, fetched[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package slack
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+
+	"github.com/Jacobus52999/go-slackjira/bridge/core"
+)
+
+const (
+	callbackPrefix = "jira_issue:"
+
+	actionAssignMe           = "assign_me"
+	actionTransitionProgress = "transition_in_progress"
+	actionTransitionDone     = "transition_done"
+	actionComment            = "comment"
+
+	commentDialogCallbackID = "jira_comment_dialog"
+	maxSignatureAge         = 5 * time.Minute
+)
+
+func issueCallbackID(key string) string {
+	return callbackPrefix + key
+}
+
+func issueKeyFromCallbackID(callbackID string) string {
+	return strings.TrimPrefix(callbackID, callbackPrefix)
+}
+
+func issueActions() []slack.AttachmentAction {
+	return []slack.AttachmentAction{
+		{Name: actionAssignMe, Text: "Assign to me", Type: "button"},
+		{Name: actionTransitionProgress, Text: "In Progress", Type: "button", Value: "In Progress"},
+		{Name: actionTransitionDone, Text: "Done", Type: "button", Value: "Done"},
+		{Name: actionComment, Text: "Comment", Type: "button"},
+	}
+}
+
+// InteractionHandler is an http.Handler that receives Slack's
+// interactive-component POSTs (button clicks, dialog submissions, message
+// shortcuts) and dispatches them to a core.Exporter.
+type InteractionHandler struct {
+	signingSecret string
+	client        *slack.Client
+	exporter      core.Exporter
+}
+
+// NewInteractionHandler builds an InteractionHandler. signingSecret is the
+// Slack app's signing secret, used to verify every incoming request.
+func NewInteractionHandler(signingSecret string, client *slack.Client, exporter core.Exporter) *InteractionHandler {
+	return &InteractionHandler{signingSecret: signingSecret, client: client, exporter: exporter}
+}
+
+// ServeHTTP implements http.Handler for Slack's interactive-components
+// endpoint (button clicks, message shortcuts and dialog submissions all
+// arrive here as a form-encoded "payload" field).
+func (h *InteractionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "error reading body", http.StatusBadRequest)
+		return
+	}
+	r.Body.Close()
+
+	if err := VerifySigningSecret(h.signingSecret, r.Header, body); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.Body = io.NopCloser(strings.NewReader(string(body)))
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "error parsing form", http.StatusBadRequest)
+		return
+	}
+
+	var callback slack.InteractionCallback
+	if err := json.Unmarshal([]byte(r.FormValue("payload")), &callback); err != nil {
+		http.Error(w, "error parsing payload", http.StatusBadRequest)
+		return
+	}
+
+	switch callback.Type {
+	case "interactive_message":
+		h.handleButton(r.Context(), w, callback)
+	case "dialog_submission":
+		h.handleDialogSubmission(r.Context(), w, callback)
+	case "message_action":
+		h.handleMessageShortcut(w, callback)
+	default:
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (h *InteractionHandler) handleButton(ctx context.Context, w http.ResponseWriter, callback slack.InteractionCallback) {
+	key := issueKeyFromCallbackID(callback.CallbackID)
+	if len(callback.ActionCallback.AttachmentActions) == 0 {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	action := callback.ActionCallback.AttachmentActions[0]
+
+	var err error
+	switch action.Name {
+	case actionAssignMe:
+		err = h.exporter.AssignIssue(ctx, key, callback.User.ID)
+	case actionTransitionProgress, actionTransitionDone:
+		err = h.exporter.TransitionIssue(ctx, key, action.Value)
+	case actionComment:
+		err = h.openCommentDialog(callback.TriggerID, key)
+	}
+	if err != nil {
+		fmt.Printf("jira action %s on %s: %s\n", action.Name, key, err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *InteractionHandler) openCommentDialog(triggerID, issueKey string) error {
+	dialog := slack.Dialog{
+		CallbackID:  commentDialogCallbackID + ":" + issueKey,
+		Title:       "Comment on " + issueKey,
+		SubmitLabel: "Send",
+		Elements: []slack.DialogElement{
+			slack.DialogInput{
+				Type:     slack.InputTypeTextArea,
+				Label:    "Comment",
+				Name:     "comment",
+				Optional: false,
+			},
+		},
+	}
+	return h.client.OpenDialog(triggerID, dialog)
+}
+
+func (h *InteractionHandler) handleDialogSubmission(ctx context.Context, w http.ResponseWriter, callback slack.InteractionCallback) {
+	if !strings.HasPrefix(callback.CallbackID, commentDialogCallbackID+":") {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	key := strings.TrimPrefix(callback.CallbackID, commentDialogCallbackID+":")
+	comment := callback.Submission["comment"]
+	if err := h.exporter.AddComment(ctx, key, comment); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleMessageShortcut opens a dialog pre-filled with the shortcut's
+// originating message so a Slack discussion can be archived back to the
+// ticket as a single comment. The issue key is expected as the first
+// token of the message text (e.g. "MYPROJ-123: let's discuss...").
+func (h *InteractionHandler) handleMessageShortcut(w http.ResponseWriter, callback slack.InteractionCallback) {
+	fields := strings.Fields(callback.Message.Text)
+	key := ""
+	if len(fields) > 0 {
+		key = strings.TrimSuffix(fields[0], ":")
+	}
+	dialog := slack.Dialog{
+		CallbackID:  commentDialogCallbackID + ":" + key,
+		Title:       "Archive thread to " + key,
+		SubmitLabel: "Send",
+		Elements: []slack.DialogElement{
+			slack.NewTextAreaInput("comment", "Comment", callback.Message.Text),
+		},
+	}
+	if err := h.client.OpenDialog(callback.TriggerID, dialog); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// HandleSlashCommand implements the `/jira <key> comment <text>` slash
+// command.
+func (h *InteractionHandler) HandleSlashCommand(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "error reading body", http.StatusBadRequest)
+		return
+	}
+	r.Body.Close()
+	if err := VerifySigningSecret(h.signingSecret, r.Header, body); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.Body = io.NopCloser(strings.NewReader(string(body)))
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "error parsing form", http.StatusBadRequest)
+		return
+	}
+
+	parts := strings.SplitN(strings.TrimSpace(r.FormValue("text")), " ", 3)
+	if len(parts) < 3 || parts[1] != "comment" {
+		http.Error(w, "usage: /jira <key> comment <text>", http.StatusOK)
+		return
+	}
+	key, comment := parts[0], parts[2]
+	if err := h.exporter.AddComment(r.Context(), key, comment); err != nil {
+		http.Error(w, err.Error(), http.StatusOK)
+		return
+	}
+	w.Write([]byte(fmt.Sprintf("Comment added to %s.", key)))
+}
+
+// VerifySigningSecret checks Slack's request signature as documented at
+// https://api.slack.com/authentication/verifying-requests-from-slack:
+// HMAC-SHA256 of "v0:{timestamp}:{body}" keyed by the app's signing
+// secret must match the X-Slack-Signature header.
+func VerifySigningSecret(signingSecret string, header http.Header, body []byte) error {
+	ts := header.Get("X-Slack-Request-Timestamp")
+	sig := header.Get("X-Slack-Signature")
+	if ts == "" || sig == "" {
+		return fmt.Errorf("slack: missing signature headers")
+	}
+	if sec, err := strconv.ParseInt(ts, 10, 64); err != nil || time.Since(time.Unix(sec, 0)) > maxSignatureAge {
+		return fmt.Errorf("slack: request timestamp too old, possible replay")
+	}
+
+	mac := hmac.New(sha256.New, []byte(signingSecret))
+	fmt.Fprintf(mac, "v0:%s:%s", ts, body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(sig)) != 1 {
+		return fmt.Errorf("slack: signature mismatch")
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package slack
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func sign(secret, ts string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "v0:%s:%s", ts, body)
+	return "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifySigningSecretValid(t *testing.T) {
+	secret := "shhh"
+	body := []byte(`{"type":"message_action"}`)
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+
+	header := http.Header{}
+	header.Set("X-Slack-Request-Timestamp", ts)
+	header.Set("X-Slack-Signature", sign(secret, ts, body))
+
+	if err := VerifySigningSecret(secret, header, body); err != nil {
+		t.Fatalf("VerifySigningSecret() = %v, want nil", err)
+	}
+}
+
+func TestVerifySigningSecretWrongSecret(t *testing.T) {
+	body := []byte(`{"type":"message_action"}`)
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+
+	header := http.Header{}
+	header.Set("X-Slack-Request-Timestamp", ts)
+	header.Set("X-Slack-Signature", sign("wrong-secret", ts, body))
+
+	if err := VerifySigningSecret("shhh", header, body); err == nil {
+		t.Fatal("VerifySigningSecret() = nil, want error for mismatched signature")
+	}
+}
+
+func TestVerifySigningSecretTamperedBody(t *testing.T) {
+	secret := "shhh"
+	ts := strconv.FormatInt(time.Now().Unix(), 10)
+
+	header := http.Header{}
+	header.Set("X-Slack-Request-Timestamp", ts)
+	header.Set("X-Slack-Signature", sign(secret, ts, []byte("original body")))
+
+	if err := VerifySigningSecret(secret, header, []byte("tampered body")); err == nil {
+		t.Fatal("VerifySigningSecret() = nil, want error for tampered body")
+	}
+}
+
+func TestVerifySigningSecretOldTimestamp(t *testing.T) {
+	secret := "shhh"
+	body := []byte(`{"type":"message_action"}`)
+	ts := strconv.FormatInt(time.Now().Add(-maxSignatureAge-time.Minute).Unix(), 10)
+
+	header := http.Header{}
+	header.Set("X-Slack-Request-Timestamp", ts)
+	header.Set("X-Slack-Signature", sign(secret, ts, body))
+
+	if err := VerifySigningSecret(secret, header, body); err == nil {
+		t.Fatal("VerifySigningSecret() = nil, want error for stale timestamp")
+	}
+}
+
+func TestVerifySigningSecretMissingHeaders(t *testing.T) {
+	if err := VerifySigningSecret("shhh", http.Header{}, []byte("body")); err == nil {
+		t.Fatal("VerifySigningSecret() = nil, want error for missing signature headers")
+	}
+}
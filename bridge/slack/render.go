@@ -0,0 +1,84 @@
+package slack
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/slack-go/slack"
+	"gopkg.in/yaml.v2"
+
+	"github.com/Jacobus52999/go-slackjira/bridge/core"
+)
+
+// TemplateConfig describes which fields an unfurl renders and how, loaded
+// from a user-editable YAML file so operators can add fields (Status,
+// Reporter, Labels, Components, Fix Version, Sprint, Story Points, linked
+// issues, recent comments, ...) without a code change.
+type TemplateConfig struct {
+	Fields []FieldTemplate `yaml:"fields"`
+}
+
+// FieldTemplate renders one slack.AttachmentField from a core.Issue.
+type FieldTemplate struct {
+	Title    string `yaml:"title"`
+	Template string `yaml:"template"`
+	Short    bool   `yaml:"short"`
+}
+
+// defaultTemplateConfig reproduces the bridge's original hardcoded
+// attachment (Summary/Assignee/Priority) for operators who don't ship a
+// config file.
+func defaultTemplateConfig() *TemplateConfig {
+	return &TemplateConfig{
+		Fields: []FieldTemplate{
+			{Title: "Assignee", Template: "{{ .Assignee }}", Short: true},
+			{Title: "Priority", Template: "{{ .Priority }}", Short: true},
+		},
+	}
+}
+
+// LoadTemplateConfig reads a TemplateConfig from a YAML file at path.
+func LoadTemplateConfig(path string) (*TemplateConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("slack: reading template config: %w", err)
+	}
+	var cfg TemplateConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("slack: parsing template config: %w", err)
+	}
+	return &cfg, nil
+}
+
+var templateFuncs = template.FuncMap{
+	"join": strings.Join,
+}
+
+// render executes cfg's field templates against issue, producing the
+// slack.AttachmentField entries for its unfurl.
+func (cfg *TemplateConfig) render(issue core.Issue) ([]slack.AttachmentField, error) {
+	fields := make([]slack.AttachmentField, 0, len(cfg.Fields))
+	for _, ft := range cfg.Fields {
+		tmpl, err := template.New(ft.Title).Funcs(templateFuncs).Parse(ft.Template)
+		if err != nil {
+			return nil, fmt.Errorf("slack: parsing template for field %q: %w", ft.Title, err)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, issue); err != nil {
+			return nil, fmt.Errorf("slack: rendering field %q: %w", ft.Title, err)
+		}
+		value := buf.String()
+		if value == "" {
+			continue
+		}
+		fields = append(fields, slack.AttachmentField{
+			Title: ft.Title,
+			Value: value,
+			Short: ft.Short,
+		})
+	}
+	return fields, nil
+}
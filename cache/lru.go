@@ -0,0 +1,81 @@
+// Package cache provides a small in-memory LRU cache with per-entry TTL,
+// used by bridge implementations to avoid re-fetching tracker state that
+// was already fetched within a short window.
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// LRU is a fixed-capacity, TTL-expiring cache. It is safe for concurrent
+// use.
+type LRU struct {
+	mu       sync.Mutex
+	capacity int
+	ttl      time.Duration
+	order    *list.List
+	items    map[string]*list.Element
+}
+
+type entry struct {
+	key     string
+	value   interface{}
+	expires time.Time
+}
+
+// New builds an LRU holding at most capacity entries, each valid for ttl
+// after being Set.
+func New(capacity int, ttl time.Duration) *LRU {
+	return &LRU{
+		capacity: capacity,
+		ttl:      ttl,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Get returns the cached value for key if present and not expired.
+func (c *LRU) Get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	e := elem.Value.(*entry)
+	if time.Now().After(e.expires) {
+		c.order.Remove(elem)
+		delete(c.items, key)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return e.value, true
+}
+
+// Set stores value under key, evicting the least-recently-used entry if
+// the cache is at capacity.
+func (c *LRU) Set(key string, value interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		elem.Value.(*entry).value = value
+		elem.Value.(*entry).expires = time.Now().Add(c.ttl)
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	elem := c.order.PushFront(&entry{key: key, value: value, expires: time.Now().Add(c.ttl)})
+	c.items[key] = elem
+
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.items, oldest.Value.(*entry).key)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRUGetSet(t *testing.T) {
+	c := New(10, time.Minute)
+
+	if _, ok := c.Get("missing"); ok {
+		t.Fatal("Get on empty cache returned ok=true")
+	}
+
+	c.Set("a", 1)
+	value, ok := c.Get("a")
+	if !ok || value != 1 {
+		t.Fatalf("Get(a) = %v, %v; want 1, true", value, ok)
+	}
+}
+
+func TestLRUExpiry(t *testing.T) {
+	c := New(10, time.Millisecond)
+
+	c.Set("a", 1)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("Get returned an entry past its TTL")
+	}
+}
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	c := New(2, time.Minute)
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a") // a is now most recently used; b is the eviction candidate
+	c.Set("c", 3)
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("Get(b) = _, true; want evicted")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("Get(a) = _, false; want present")
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Fatal("Get(c) = _, false; want present")
+	}
+}
+
+func TestLRUSetOverwritesValue(t *testing.T) {
+	c := New(10, time.Minute)
+
+	c.Set("a", 1)
+	c.Set("a", 2)
+
+	value, ok := c.Get("a")
+	if !ok || value != 2 {
+		t.Fatalf("Get(a) = %v, %v; want 2, true", value, ok)
+	}
+}
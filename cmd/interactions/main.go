@@ -0,0 +1,64 @@
+// Command interactions runs the HTTP endpoints Slack posts interactive
+// components to: button clicks and dialog submissions from unfurled
+// issues, the `/jira` slash command, and the "archive to Jira" message
+// shortcut. It shares the Jira credential store with the main bridge, and
+// routes each action to the correct instance when more than one is
+// configured.
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	slackgo "github.com/slack-go/slack"
+
+	"github.com/Jacobus52999/go-slackjira/bridge/slack"
+	"github.com/Jacobus52999/go-slackjira/config"
+)
+
+const (
+	envConfigPath      = "CONFIG_PATH"
+	envSlackToken      = "SLACK_TOKEN"
+	envSigningSecret   = "SLACK_SIGNING_SECRET"
+	envInteractionAddr = "INTERACTIONS_ADDR"
+
+	defaultConfigPath = "go-slackjira.yaml"
+	defaultAddr       = ":3000"
+)
+
+func main() {
+	configPath := os.Getenv(envConfigPath)
+	if configPath == "" {
+		configPath = defaultConfigPath
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		fmt.Printf("loading config: %s\n", err)
+		os.Exit(1)
+	}
+	router, err := cfg.BuildRouter(context.Background())
+	if err != nil {
+		fmt.Printf("building Jira router: %s\n", err)
+		os.Exit(1)
+	}
+
+	signingSecret := os.Getenv(envSigningSecret)
+	slackClient := slackgo.New(os.Getenv(envSlackToken))
+	handler := slack.NewInteractionHandler(signingSecret, slackClient, router)
+
+	mux := http.NewServeMux()
+	mux.Handle("/slack/interactions", handler)
+	mux.HandleFunc("/slack/commands", handler.HandleSlashCommand)
+
+	addr := os.Getenv(envInteractionAddr)
+	if addr == "" {
+		addr = defaultAddr
+	}
+	fmt.Printf("interactions listening on %s\n", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		fmt.Printf("interactions server stopped: %s\n", err)
+		os.Exit(1)
+	}
+}
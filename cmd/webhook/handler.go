@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	slackgo "github.com/slack-go/slack"
+
+	"github.com/Jacobus52999/go-slackjira/bridge/jira"
+	"github.com/Jacobus52999/go-slackjira/bridge/slack"
+	"github.com/Jacobus52999/go-slackjira/subscriptions"
+)
+
+type handler struct {
+	router        *jira.Router
+	store         subscriptions.Store
+	slack         *slackgo.Client
+	webhookSecret string
+	signingSecret string
+}
+
+// handleWebhook receives a Jira webhook POST, matches it against stored
+// subscriptions and posts a notification to every channel that matches.
+func (h *handler) handleWebhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "error reading body", http.StatusBadRequest)
+		return
+	}
+	r.Body.Close()
+
+	if err := jira.VerifyWebhookSignature(h.webhookSecret, r.Header.Get("X-Webhook-Signature"), body); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	event, err := jira.ParseWebhookEvent(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	key := event.IssueKey()
+
+	subs, err := h.store.List(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	for _, sub := range subs {
+		if !sub.Matches(event.Type) {
+			continue
+		}
+		if key == "" {
+			// Board-level events (e.g. sprint_started) have no issue to
+			// run sub.JQLFilter against, so only deliver them to
+			// subscriptions that didn't ask for one.
+			if sub.JQLFilter != "" {
+				continue
+			}
+			h.notify(sub.Channel, event.BoardContext(), event)
+			continue
+		}
+		client, err := h.router.ClientFor(key)
+		if err != nil {
+			continue
+		}
+		jql := fmt.Sprintf("key = %s", key)
+		if sub.JQLFilter != "" {
+			jql = fmt.Sprintf("%s AND (%s)", jql, sub.JQLFilter)
+		}
+		matches, err := client.Search(r.Context(), jql)
+		if err != nil || len(matches) == 0 {
+			continue
+		}
+		h.notify(sub.Channel, key, event)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// notify posts a notification for event to channel, titled title (an
+// issue key for issue-level events, a board description for board-level
+// ones such as sprint_started).
+func (h *handler) notify(channel, title string, event *jira.WebhookEvent) {
+	lines := append([]string{event.Type}, event.ChangeSummary()...)
+	color := ""
+	if _, to, changed := event.StatusChange(); changed {
+		color = slack.ColorForStatus(to)
+	}
+	attachment := slackgo.Attachment{
+		Title: title,
+		Text:  strings.Join(lines, "\n"),
+		Color: color,
+	}
+	_, _, err := h.slack.PostMessage(channel,
+		slackgo.MsgOptionUsername("Jira"),
+		slackgo.MsgOptionAttachments(attachment))
+	if err != nil {
+		fmt.Printf("posting webhook notification for %s to %s: %s\n", title, channel, err)
+	}
+}
+
+// handleSubscribeCommand implements `/jira subscribe <jql>`.
+func (h *handler) handleSubscribeCommand(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "error reading body", http.StatusBadRequest)
+		return
+	}
+	r.Body.Close()
+	if err := slack.VerifySigningSecret(h.signingSecret, r.Header, body); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	r.Body = io.NopCloser(strings.NewReader(string(body)))
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "error parsing form", http.StatusBadRequest)
+		return
+	}
+
+	jql := strings.TrimSpace(r.FormValue("text"))
+	if rest := strings.TrimPrefix(jql, "subscribe "); rest != jql {
+		jql = strings.TrimSpace(rest)
+	}
+	channel := r.FormValue("channel_id")
+	if jql == "" || channel == "" {
+		http.Error(w, "usage: /jira subscribe <jql>", http.StatusOK)
+		return
+	}
+	if _, err := h.router.Search(r.Context(), jql); err != nil {
+		fmt.Fprintf(w, "invalid JQL filter: %s", err)
+		return
+	}
+
+	sub := subscriptions.Subscription{Channel: channel, JQLFilter: jql}
+	if err := h.store.Add(r.Context(), sub); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	fmt.Fprintf(w, "Subscribed this channel to Jira events matching: %s", jql)
+}
@@ -0,0 +1,81 @@
+// Command webhook receives Jira webhook POSTs and pushes formatted
+// notifications to every Slack channel subscribed to them, and exposes
+// the `/jira subscribe <jql>` slash command used to manage subscriptions.
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	slackgo "github.com/slack-go/slack"
+
+	"github.com/Jacobus52999/go-slackjira/config"
+	"github.com/Jacobus52999/go-slackjira/subscriptions"
+)
+
+const (
+	envConfigPath     = "CONFIG_PATH"
+	envSlackToken     = "SLACK_TOKEN"
+	envSigningSecret  = "SLACK_SIGNING_SECRET"
+	envWebhookSecret  = "JIRA_WEBHOOK_SECRET"
+	envSubscriptionDB = "SUBSCRIPTIONS_DB"
+	envAddr           = "WEBHOOK_ADDR"
+
+	defaultConfigPath     = "go-slackjira.yaml"
+	defaultSubscriptionDB = "subscriptions.db"
+	defaultAddr           = ":3001"
+)
+
+func main() {
+	configPath := os.Getenv(envConfigPath)
+	if configPath == "" {
+		configPath = defaultConfigPath
+	}
+	cfg, err := config.Load(configPath)
+	if err != nil {
+		fmt.Printf("loading config: %s\n", err)
+		os.Exit(1)
+	}
+	router, err := cfg.BuildRouter(context.Background())
+	if err != nil {
+		fmt.Printf("building Jira router: %s\n", err)
+		os.Exit(1)
+	}
+
+	dbPath := os.Getenv(envSubscriptionDB)
+	if dbPath == "" {
+		dbPath = defaultSubscriptionDB
+	}
+	store, err := subscriptions.OpenBoltStore(dbPath)
+	if err != nil {
+		fmt.Printf("opening subscription store: %s\n", err)
+		os.Exit(1)
+	}
+	defer store.Close()
+
+	slackClient := slackgo.New(os.Getenv(envSlackToken))
+
+	h := &handler{
+		router:        router,
+		store:         store,
+		slack:         slackClient,
+		webhookSecret: os.Getenv(envWebhookSecret),
+		signingSecret: os.Getenv(envSigningSecret),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/jira/webhook", h.handleWebhook)
+	mux.HandleFunc("/slack/commands/subscribe", h.handleSubscribeCommand)
+
+	addr := os.Getenv(envAddr)
+	if addr == "" {
+		addr = defaultAddr
+	}
+	fmt.Printf("webhook listening on %s\n", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		fmt.Printf("webhook server stopped: %s\n", err)
+		os.Exit(1)
+	}
+}
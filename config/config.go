@@ -0,0 +1,102 @@
+// Package config loads the list of Jira instances a bot should bridge
+// into Slack, so main can build one jira.Client (and bridge/slack.Route)
+// per instance instead of assuming a single tracker, with per-instance
+// field selection and custom field IDs.
+package config
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/Jacobus52999/go-slackjira/bridge/jira"
+	"github.com/Jacobus52999/go-slackjira/credentials"
+)
+
+// Instance describes one Jira tracker to bridge. ProjectKeys may be left
+// empty to have the caller autodiscover them via Client.GetProjects.
+type Instance struct {
+	Name         string   `yaml:"name"`
+	BaseURL      string   `yaml:"base_url"`
+	CredentialID string   `yaml:"credential_id"`
+	ProjectKeys  []string `yaml:"project_keys"`
+
+	// Fields restricts what FetchIssue requests from this instance (via
+	// Jira's `?fields=`) for unfurls. Leave empty to fetch Jira's default
+	// field set.
+	Fields []string `yaml:"fields"`
+	// CustomFields maps a logical name an unfurl template can reference
+	// (e.g. "sprint", "storyPoints") to this instance's Jira custom field
+	// ID (e.g. "customfield_10010"); IDs differ between instances even for
+	// the same logical field.
+	CustomFields map[string]string `yaml:"custom_fields"`
+}
+
+// Config is the top-level shape of the YAML file Load reads.
+type Config struct {
+	Instances []Instance `yaml:"instances"`
+}
+
+// Load reads and parses the instance list at path.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config: reading %s: %w", path, err)
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("config: parsing %s: %w", path, err)
+	}
+	if len(cfg.Instances) == 0 {
+		return nil, fmt.Errorf("config: %s declares no instances", path)
+	}
+	for i, inst := range cfg.Instances {
+		if inst.Name == "" {
+			return nil, fmt.Errorf("config: instance %d in %s has no name", i, path)
+		}
+		if inst.BaseURL == "" {
+			return nil, fmt.Errorf("config: instance %q in %s has no base_url", inst.Name, path)
+		}
+		if inst.CredentialID == "" {
+			return nil, fmt.Errorf("config: instance %q in %s has no credential_id", inst.Name, path)
+		}
+	}
+	return &cfg, nil
+}
+
+// BuildRouter builds a jira.Client for every configured instance,
+// resolving each one's credential and, when ProjectKeys is left empty,
+// autodiscovering it via Client.GetProjects, then registers it with a
+// jira.Router so the caller can dispatch by issue key across however
+// many instances are configured.
+func (cfg *Config) BuildRouter(ctx context.Context) (*jira.Router, error) {
+	router := jira.NewRouter()
+	for _, inst := range cfg.Instances {
+		baseURL, err := url.Parse(inst.BaseURL)
+		if err != nil {
+			return nil, fmt.Errorf("config: invalid base_url for instance %q: %w", inst.Name, err)
+		}
+		cred, err := credentials.Load(inst.CredentialID)
+		if err != nil {
+			return nil, fmt.Errorf("config: loading credential %q for instance %q: %w", inst.CredentialID, inst.Name, err)
+		}
+		client := jira.NewClient(baseURL, cred).WithCustomFields(inst.CustomFields)
+
+		projectKeys := inst.ProjectKeys
+		if len(projectKeys) == 0 {
+			projects, err := client.GetProjects(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("config: fetching Jira projects for instance %q: %w", inst.Name, err)
+			}
+			projectKeys = make([]string, len(projects))
+			for i, p := range projects {
+				projectKeys[i] = p.Key
+			}
+		}
+		router.Add(client, projectKeys)
+	}
+	return router, nil
+}
@@ -0,0 +1,91 @@
+// Package credentials resolves the core.Authenticator used to talk to a
+// tracker instance from a durable store, instead of reading
+// JIRA_USER/JIRA_PASSWORD straight out of the environment. Secrets live in
+// the OS keyring when one is available, falling back to a JSON file under
+// ~/.config/go-slackjira otherwise.
+package credentials
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Jacobus52999/go-slackjira/bridge/core"
+)
+
+// LoginPassword authenticates with HTTP Basic auth (a username plus a
+// password or, for Jira Cloud, an API token used as the password).
+type LoginPassword struct {
+	Username string
+	Password string
+}
+
+// Authenticate implements core.Authenticator.
+func (c LoginPassword) Authenticate(req *http.Request) error {
+	req.SetBasicAuth(c.Username, c.Password)
+	return nil
+}
+
+// Token authenticates with a bearer token, e.g. an OAuth 2.0 access token
+// or a Jira Server/Data Center personal access token.
+type Token struct {
+	Value string
+}
+
+// Authenticate implements core.Authenticator.
+func (c Token) Authenticate(req *http.Request) error {
+	if c.Value == "" {
+		return fmt.Errorf("credentials: token credential has no value")
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Value)
+	return nil
+}
+
+// Kind tags a Record so a Store knows which concrete Credential type to
+// rebuild on Get.
+type Kind string
+
+const (
+	KindLoginPassword Kind = "login_password"
+	KindToken         Kind = "token"
+)
+
+// Record is the serialized form of a credential, as stored in a Store.
+type Record struct {
+	ID       string `json:"id"`
+	Kind     Kind   `json:"kind"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Token    string `json:"token,omitempty"`
+}
+
+// Authenticator builds the core.Authenticator a Record describes.
+func (r Record) Authenticator() (core.Authenticator, error) {
+	switch r.Kind {
+	case KindLoginPassword:
+		return LoginPassword{Username: r.Username, Password: r.Password}, nil
+	case KindToken:
+		return Token{Value: r.Token}, nil
+	default:
+		return nil, fmt.Errorf("credentials: unknown credential kind %q for %q", r.Kind, r.ID)
+	}
+}
+
+// Store persists credential Records keyed by ID.
+type Store interface {
+	Get(id string) (Record, error)
+	Put(rec Record) error
+}
+
+// Load opens the default store (keyring, falling back to the JSON file
+// store) and resolves id to a core.Authenticator.
+func Load(id string) (core.Authenticator, error) {
+	store, err := Open()
+	if err != nil {
+		return nil, err
+	}
+	rec, err := store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	return rec.Authenticator()
+}
@@ -0,0 +1,88 @@
+package credentials
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// FileStore is the JSON-file fallback used when no OS keyring backend is
+// available (e.g. a headless server). All records live in a single file,
+// ~/.config/go-slackjira/credentials.json, keyed by ID.
+type FileStore struct {
+	path string
+}
+
+func configDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(home, ".config", "go-slackjira")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// OpenFileStore opens (without requiring it to already exist)
+// ~/.config/go-slackjira/credentials.json.
+func OpenFileStore() (*FileStore, error) {
+	dir, err := configDir()
+	if err != nil {
+		return nil, err
+	}
+	return &FileStore{path: filepath.Join(dir, "credentials.json")}, nil
+}
+
+func (s *FileStore) readAll() (map[string]Record, error) {
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return map[string]Record{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("credentials: reading %s: %w", s.path, err)
+	}
+	var records map[string]Record
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("credentials: parsing %s: %w", s.path, err)
+	}
+	return records, nil
+}
+
+// Get implements Store.
+func (s *FileStore) Get(id string) (Record, error) {
+	records, err := s.readAll()
+	if err != nil {
+		return Record{}, err
+	}
+	rec, ok := records[id]
+	if !ok {
+		return Record{}, fmt.Errorf("credentials: no entry for %q in %s", id, s.path)
+	}
+	return rec, nil
+}
+
+// Put implements Store.
+func (s *FileStore) Put(rec Record) error {
+	records, err := s.readAll()
+	if err != nil {
+		return err
+	}
+	records[rec.ID] = rec
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0600)
+}
+
+// Open returns the OS keyring store, falling back to the JSON file store
+// when no keyring backend is usable.
+func Open() (Store, error) {
+	if ring, err := OpenKeyringStore(); err == nil {
+		return ring, nil
+	}
+	return OpenFileStore()
+}
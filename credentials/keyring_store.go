@@ -0,0 +1,51 @@
+package credentials
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/99designs/keyring"
+)
+
+const serviceName = "go-slackjira"
+
+// KeyringStore persists credentials in the OS keyring (macOS Keychain,
+// Secret Service on Linux, Windows Credential Manager, ...) via
+// 99designs/keyring.
+type KeyringStore struct {
+	ring keyring.Keyring
+}
+
+// OpenKeyringStore opens the OS keyring under go-slackjira's service name.
+func OpenKeyringStore() (*KeyringStore, error) {
+	ring, err := keyring.Open(keyring.Config{ServiceName: serviceName})
+	if err != nil {
+		return nil, fmt.Errorf("credentials: opening OS keyring: %w", err)
+	}
+	return &KeyringStore{ring: ring}, nil
+}
+
+// Get implements Store.
+func (s *KeyringStore) Get(id string) (Record, error) {
+	item, err := s.ring.Get(id)
+	if err != nil {
+		return Record{}, fmt.Errorf("credentials: no keyring entry for %q: %w", id, err)
+	}
+	var rec Record
+	if err := json.Unmarshal(item.Data, &rec); err != nil {
+		return Record{}, fmt.Errorf("credentials: decoding keyring entry for %q: %w", id, err)
+	}
+	return rec, nil
+}
+
+// Put implements Store.
+func (s *KeyringStore) Put(rec Record) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	return s.ring.Set(keyring.Item{
+		Key:  rec.ID,
+		Data: data,
+	})
+}
@@ -0,0 +1,59 @@
+// Package logging provides the small structured logger used across the
+// bot and cmd binaries: one JSON object per line, with a level/message
+// and arbitrary key/value fields, so transient failures (a dropped
+// unfurl, a retried Jira call) show up in log aggregation instead of
+// scrolling past in plain text.
+package logging
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+)
+
+// Logger writes structured log lines to an io.Writer (os.Stderr by
+// default).
+type Logger struct {
+	out io.Writer
+}
+
+// New builds a Logger writing to os.Stderr.
+func New() *Logger {
+	return &Logger{out: os.Stderr}
+}
+
+// Field is one key/value pair attached to a log line.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// F is shorthand for constructing a Field.
+func F(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+func (l *Logger) write(level, msg string, fields []Field) {
+	entry := make(map[string]interface{}, len(fields)+3)
+	entry["time"] = time.Now().Format(time.RFC3339)
+	entry["level"] = level
+	entry["msg"] = msg
+	for _, f := range fields {
+		entry[f.Key] = f.Value
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	l.out.Write(append(data, '\n'))
+}
+
+// Info logs an informational line.
+func (l *Logger) Info(msg string, fields ...Field) { l.write("info", msg, fields) }
+
+// Warn logs a warning line, e.g. a retried request.
+func (l *Logger) Warn(msg string, fields ...Field) { l.write("warn", msg, fields) }
+
+// Error logs an error line, e.g. a dropped unfurl.
+func (l *Logger) Error(msg string, fields ...Field) { l.write("error", msg, fields) }
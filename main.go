@@ -1,279 +1,120 @@
+// Command go-slackjira wires one or more bridge/jira.Clients to a
+// bridge/slack.Bridge, then runs it through the bot package's worker pool
+// so issue keys mentioned in Slack get unfurled with their Jira details.
+// See bridge/core for the interfaces that let either side be swapped for
+// a different tracker or chat backend.
 package main
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
+	"flag"
 	"fmt"
-	"io/ioutil"
-	"net/http"
 	"net/url"
 	"os"
-	"regexp"
-	"strings"
-	"sync"
+	"runtime"
 	"time"
 
-	"github.com/nlopes/slack"
+	"github.com/Jacobus52999/go-slackjira/bot"
+	"github.com/Jacobus52999/go-slackjira/bridge/jira"
+	"github.com/Jacobus52999/go-slackjira/bridge/slack"
+	"github.com/Jacobus52999/go-slackjira/config"
+	"github.com/Jacobus52999/go-slackjira/credentials"
+	"github.com/Jacobus52999/go-slackjira/logging"
 )
 
 const (
-	jiraURL      = "JIRA_URL"
-	jiraUser     = "JIRA_USER"
-	jiraPassword = "JIRA_PASSWORD"
-	jiraIcon     = "https://globus.atlassian.net/images/64jira.png"
-	slackToken   = "SLACK_TOKEN"
-	issueURL     = "/rest/api/2/issue/"
-	projectsURL  = "/rest/api/2/project"
-	yellow       = "#FFD442"
-	green        = "#048A25"
-	blue         = "#496686"
+	envConfigPath     = "CONFIG_PATH"
+	envSlackToken     = "SLACK_TOKEN"
+	envSlackAppToken  = "SLACK_APP_TOKEN"
+	envSigningSecret  = "SLACK_SIGNING_SECRET"
+	envEventsAddr     = "EVENTS_ADDR"
+	envUnfurlTemplate = "UNFURL_TEMPLATE"
+
+	defaultConfigPath = "go-slackjira.yaml"
+
+	unfurlCacheSize   = 512
+	unfurlCacheTTL    = 5 * time.Minute
+	unfurlDedupWindow = 30 * time.Second
 )
 
-type (
-	// Project Jira project
-	Project struct {
-		ID  string `json:"id"`
-		KEY string `json:"key"`
-	}
-	// JiraClient http client for connecting to the Jira server
-	JiraClient struct {
-		username   string
-		password   string
-		baseURL    *url.URL
-		httpClient *http.Client
-	}
-
-	//Issue Jira issue
-	Issue struct {
-		Key    string
-		Fields *IssueFields
-	}
-	//IssueFields fields for Jira issue
-	IssueFields struct {
-		IssueType *IssueType
-		Summary   string
-		Creator   *Creator
-		Assignee  *Assignee
-		Priority  *Priority
-		Status    *Status
-	}
-
-	//IssueType Jira issue type e.g Task,Bug etc
-	IssueType struct {
-		IconURL string
-		Name    string
-	}
-
-	//Creator Jira issue creator
-	Creator struct {
-		DisplayName string
-	}
-
-	//Assignee Jira issue assignee
-	Assignee struct {
-		DisplayName string
-	}
-
-	//Priority Jira issue priority
-	Priority struct {
-		Name    string
-		IconURL string
-	}
-
-	//Status Jira issue status, e.g open closed
-	Status struct {
-		Name    string
-		IconURL string
-	}
-)
-
-var (
-	//Pattern hold the issue regex
-	Pattern *regexp.Regexp
-	//Projects all of the Jira projects
-	Projects = []Project{}
-	//Slack slack client
-	Slack *slack.Client
-	//Client JiraClient
-	Client            JiraClient
-	jiraHostURL       string
-	jiraUserName      string
-	jiraiUserPassword string
-	slackAPIToken     string
-)
-
-//NewClient new jira client
-func NewClient(username, password string, baseURL *url.URL) JiraClient {
-	return JiraClient{
-		username:   username,
-		password:   password,
-		baseURL:    baseURL,
-		httpClient: &http.Client{Timeout: 10 * time.Second},
-	}
-}
-
-//GetProjects returns a representation of a Jira project for the given project key.  An example of a key is MYPROJ.
-func (client JiraClient) GetProjects() error {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s%s", client.baseURL, projectsURL), nil)
-	if err != nil {
-		return err
-	}
-	req.Header.Set("Accept", "application/json")
-	req.SetBasicAuth(client.username, client.password)
-
-	responseCode, data, err := client.consumeResponse(req)
-	if err != nil {
-		return err
-	}
-	if responseCode != http.StatusOK {
-		return fmt.Errorf("error getting project.  Status code: %d.\n", responseCode)
-	}
-
-	if err := json.Unmarshal(data, &Projects); err != nil {
-		return err
-
-	}
-	return nil
-}
-
-//GetIssue serach jira for an issue
-func (client JiraClient) GetIssue(issuekey string) (Issue, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s%s%s", client.baseURL, issueURL, issuekey), nil)
-	var issue Issue
-	if err != nil {
-		return issue, err
-	}
-	req.Header.Set("Accept", "application/json")
-	req.SetBasicAuth(client.username, client.password)
+func main() {
+	workers := flag.Int("workers", runtime.NumCPU(), "number of concurrent unfurl workers")
+	mode := flag.String("mode", "socket", "Slack ingestion mode: socket or events")
+	flag.Parse()
 
-	responseCode, data, err := client.consumeResponse(req)
-	if err != nil {
-		return issue, err
-	}
+	log := logging.New()
 
-	if responseCode != http.StatusOK {
-		return issue, fmt.Errorf("error getting project.  Status code: %d.\n", responseCode)
+	configPath := os.Getenv(envConfigPath)
+	if configPath == "" {
+		configPath = defaultConfigPath
 	}
-
-	if err := json.Unmarshal(data, &issue); err != nil {
-		return issue, err
-	}
-	if issue.Key == "" {
-		return issue, errors.New("No Issue were found")
-	}
-	if issue.Fields.Assignee == nil {
-		issue.Fields.Assignee = &Assignee{"Unassigned"}
-	}
-
-	return issue, nil
-}
-func (client JiraClient) consumeResponse(req *http.Request) (rc int, buffer []byte, err error) {
-	response, err := client.httpClient.Do(req)
+	cfg, err := config.Load(configPath)
 	if err != nil {
-		return response.StatusCode, nil, err
-	}
-	defer response.Body.Close()
-
-	if data, err := ioutil.ReadAll(response.Body); err == nil {
-		return response.StatusCode, data, nil
+		fmt.Printf("loading config: %s\n", err)
+		os.Exit(1)
 	}
-	return response.StatusCode, nil, err
-}
 
-func buildPattern() {
-	pattern := `(?:\W|^)((`
-	for _, p := range Projects {
-		pattern += p.KEY
-		pattern += "|"
+	var tmpl *slack.TemplateConfig
+	if path := os.Getenv(envUnfurlTemplate); path != "" {
+		tmpl, err = slack.LoadTemplateConfig(path)
+		if err != nil {
+			fmt.Printf("loading %s: %s\n", envUnfurlTemplate, err)
+			os.Exit(1)
+		}
 	}
-	pattern += `)-\d+)(\+)?|$`
-	Pattern = regexp.MustCompile(pattern)
-}
 
-func getColor(status string) (color string) {
-	switch status {
-	case "Open":
-		color = blue
-	case "Reopened":
-		color = blue
-	case "To Do":
-		color = blue
-	case "Resolved":
-		color = green
-	case "Closed":
-		color = green
-	case "Done":
-		color = green
-	default:
-		color = yellow
-
-	}
+	ctx := context.Background()
+	routes := make([]slack.Route, len(cfg.Instances))
+	for i, inst := range cfg.Instances {
+		baseURL, err := url.Parse(inst.BaseURL)
+		if err != nil {
+			fmt.Printf("invalid base_url for instance %q: %s\n", inst.Name, err)
+			os.Exit(1)
+		}
+		cred, err := credentials.Load(inst.CredentialID)
+		if err != nil {
+			fmt.Printf("loading Jira credential %q: %s\n", inst.CredentialID, err)
+			os.Exit(1)
+		}
+		client := jira.NewClient(baseURL, cred).WithCustomFields(inst.CustomFields)
+
+		projectKeys := inst.ProjectKeys
+		if len(projectKeys) == 0 {
+			projects, err := client.GetProjects(ctx)
+			if err != nil {
+				fmt.Printf("fetching Jira projects for instance %q: %s\n", inst.Name, err)
+				os.Exit(1)
+			}
+			projectKeys = make([]string, len(projects))
+			for j, p := range projects {
+				projectKeys[j] = p.Key
+			}
+		}
 
-	return color
-}
-func sendMessage(issue Issue, channel string) error {
-	params := slack.PostMessageParameters{}
-	text := fmt.Sprintf("*%s*\n\n *Assignee* %s *Priority* %s ", issue.Fields.Summary, issue.Fields.Assignee.DisplayName, issue.Fields.Priority.Name)
-	attachment := slack.Attachment{
-		Title:      issue.Key,
-		TitleLink:  fmt.Sprintf("%s/browse/%s", jiraHostURL, issue.Key),
-		Text:       text,
-		Color:      getColor(issue.Fields.Status.Name),
-		MarkdownIn: []string{"text", "pretext"},
-	}
-	params.Attachments = []slack.Attachment{attachment}
-	params.IconURL = jiraIcon
-	params.Username = "Jira"
-	_, _, err := Slack.PostMessage(channel, "", params)
-	if err != nil {
-		fmt.Printf("%s\n", err)
-		return err
+		routes[i] = slack.Route{
+			Instance:    inst.Name,
+			Importer:    jira.NewCachingClient(client, unfurlCacheSize, unfurlCacheTTL, inst.Fields),
+			ProjectKeys: projectKeys,
+		}
 	}
-	return nil
 
-}
+	chat := slack.New(os.Getenv(envSlackToken), slack.Options{
+		Routes:      routes,
+		Template:    tmpl,
+		DedupWindow: unfurlDedupWindow,
+	})
 
-func processEvents(text string, channel string, wg sync.WaitGroup) {
-	defer wg.Done()
-	matches := Pattern.FindAllStringSubmatch(text, -1)
-	for _, v := range matches {
-		if issue, err := Client.GetIssue(strings.TrimSpace(v[1])); err == nil {
-			sendMessage(issue, channel)
-		}
-	}
-}
-func main() {
-	var wg sync.WaitGroup
-	jiraHostURL = os.Getenv(jiraURL)
-	jiraUserName = os.Getenv(jiraUser)
-	jiraiUserPassword = os.Getenv(jiraPassword)
-	slackAPIToken = os.Getenv(slackToken)
-	url, _ := url.Parse(jiraHostURL)
-	Client = NewClient(jiraUserName, jiraiUserPassword, url)
-	Slack = slack.New(slackAPIToken)
-	Slack.SetDebug(false)
-	Client.GetProjects()
-	buildPattern()
-	rtm := Slack.NewRTM()
-	go rtm.ManageConnection()
+	b := bot.New(bot.Config{
+		Mode:          bot.ParseMode(*mode),
+		Workers:       *workers,
+		Token:         os.Getenv(envSlackToken),
+		AppToken:      os.Getenv(envSlackAppToken),
+		SigningSecret: os.Getenv(envSigningSecret),
+		EventsAddr:    os.Getenv(envEventsAddr),
+	}, chat, log)
 
-Loop:
-	for {
-		select {
-		case msg := <-rtm.IncomingEvents:
-			switch ev := msg.Data.(type) {
-			case *slack.MessageEvent:
-				if ev.SubType != "bot_message" {
-					wg.Add(1)
-					go processEvents(ev.Text, ev.Channel, wg)
-				}
-			case *slack.InvalidAuthEvent:
-				fmt.Printf("Invalid credentials")
-				break Loop
-			default:
-				// Ignore other events..
-			}
-		}
+	if err := b.Run(ctx); err != nil {
+		log.Error("bot stopped", logging.F("error", err.Error()))
+		os.Exit(1)
 	}
-	wg.Wait()
 }
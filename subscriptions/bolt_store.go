@@ -0,0 +1,81 @@
+package subscriptions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var subscriptionsBucket = []byte("subscriptions")
+
+// BoltStore persists subscriptions in a single-file BoltDB, keyed by
+// "channel\x00jqlFilter" so a channel can have several independent
+// filters.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// OpenBoltStore opens (creating if needed) the BoltDB at path.
+func OpenBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("subscriptions: opening %s: %w", path, err)
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(subscriptionsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &BoltStore{db: db}, nil
+}
+
+func subscriptionKey(channel, jqlFilter string) []byte {
+	return []byte(channel + "\x00" + jqlFilter)
+}
+
+// Add implements Store.
+func (s *BoltStore) Add(ctx context.Context, sub Subscription) error {
+	data, err := json.Marshal(sub)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(subscriptionsBucket).Put(subscriptionKey(sub.Channel, sub.JQLFilter), data)
+	})
+}
+
+// Remove implements Store.
+func (s *BoltStore) Remove(ctx context.Context, channel, jqlFilter string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(subscriptionsBucket).Delete(subscriptionKey(channel, jqlFilter))
+	})
+}
+
+// List implements Store.
+func (s *BoltStore) List(ctx context.Context) ([]Subscription, error) {
+	var out []Subscription
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(subscriptionsBucket).ForEach(func(_, data []byte) error {
+			var sub Subscription
+			if err := json.Unmarshal(data, &sub); err != nil {
+				return err
+			}
+			out = append(out, sub)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Close implements Store.
+func (s *BoltStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,36 @@
+// Package subscriptions stores the (channel, JQL filter, event types)
+// tuples that tell cmd/webhook which Slack channels want which Jira
+// webhook events.
+package subscriptions
+
+import "context"
+
+// Subscription binds a Slack channel to a JQL filter and the webhook
+// event types it cares about. A nil/empty EventTypes matches every event.
+type Subscription struct {
+	Channel    string
+	JQLFilter  string
+	EventTypes []string
+}
+
+// Matches reports whether eventType should be delivered to this
+// subscription.
+func (s Subscription) Matches(eventType string) bool {
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Store persists subscriptions.
+type Store interface {
+	Add(ctx context.Context, sub Subscription) error
+	Remove(ctx context.Context, channel, jqlFilter string) error
+	List(ctx context.Context) ([]Subscription, error)
+	Close() error
+}